@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
@@ -35,13 +39,24 @@ func hooksActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newHooksRunAction,
 	})
 
+	group.Add("list", &actions.ActionDescriptorOptions{
+		Command:        newHooksListCmd(),
+		FlagsResolver:  newHooksListFlags,
+		ActionResolver: newHooksListAction,
+	})
+
+	group.Add("validate", &actions.ActionDescriptorOptions{
+		Command:        newHooksValidateCmd(),
+		ActionResolver: newHooksValidateAction,
+	})
+
 	return group
 }
 
 func newHooksRunFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *hooksRunFlags {
 	flags := &hooksRunFlags{}
 	flags.Bind(cmd.Flags(), global)
-	
+
 	return flags
 }
 
@@ -58,6 +73,11 @@ type hooksRunFlags struct {
 	global   *internal.GlobalCommandOptions
 	platform string
 	service  string
+	parallel int
+	failFast bool
+	offline  bool
+	output   string
+	events   string
 }
 
 func (f *hooksRunFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
@@ -66,6 +86,39 @@ func (f *hooksRunFlags) Bind(local *pflag.FlagSet, global *internal.GlobalComman
 
 	local.StringVar(&f.platform, "platform", "", "Forces hooks to run for the specified platform.")
 	local.StringVar(&f.service, "service", "", "Only runs hooks for the specified service.")
+	local.IntVar(
+		&f.parallel,
+		"parallel",
+		1,
+		"Maximum number of hooks with no unmet dependencies and the same weight to run concurrently.",
+	)
+	local.BoolVar(
+		&f.failFast,
+		"fail-fast",
+		true,
+		"Stop scheduling further hooks after a failure. Set to false to run every independent "+
+			"hook and report all failures together.",
+	)
+	local.BoolVar(
+		&f.offline,
+		"offline",
+		false,
+		"Fail fast instead of downloading a hook's remote script when it isn't already cached.",
+	)
+	local.StringVar(
+		&f.output,
+		"output",
+		"",
+		"The output format to use. Allowed values: json. When json, lifecycle events are "+
+			"written as JSON lines instead of the interactive spinner/previewer UX, unless "+
+			"--events is also set, in which case they go to that file and the UX is kept.",
+	)
+	local.StringVar(
+		&f.events,
+		"events",
+		"",
+		"Write structured hook lifecycle events as JSON lines to the given file instead of stdout.",
+	)
 }
 
 type hooksRunAction struct {
@@ -103,19 +156,32 @@ func newHooksRunAction(
 
 const noHookFoundMessage = " (No hook found)"
 
-// 1. 获取基础信息。检查hooks是否合法。区分ProjectLevel和ServiceLevel。然后processHooks。
+// hookTarget is a single project or service hook resolved to the directory it runs from and
+// the identifier other hooks can reference via dependsOn.
+type hookTarget struct {
+	node         ext.HookNode
+	cwd          string
+	scope        string
+	service      string
+	spinnerTitle string
+	previewTitle string
+}
+
 func (hra *hooksRunAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	hookName := hra.args[0]
 
-	// Command title
-	hra.console.MessageUxItem(ctx, &ux.MessageTitle{
-		Title: "Running hooks (azd hooks run)",
-		TitleNote: fmt.Sprintf(
-			"Finding and executing %s hooks for environment %s",
-			output.WithHighLightFormat(hookName),
-			output.WithHighLightFormat(hra.env.Name()),
-		),
-	})
+	// Command title. Suppressed when JSON events are written to stdout so a line-oriented
+	// consumer never sees anything but JSON lines on that stream.
+	if !hra.jsonToStdout() {
+		hra.console.MessageUxItem(ctx, &ux.MessageTitle{
+			Title: "Running hooks (azd hooks run)",
+			TitleNote: fmt.Sprintf(
+				"Finding and executing %s hooks for environment %s",
+				output.WithHighLightFormat(hookName),
+				output.WithHighLightFormat(hra.env.Name()),
+			),
+		})
+	}
 
 	// Validate service name
 	if hra.flags.service != "" {
@@ -126,15 +192,57 @@ func (hra *hooksRunAction) Run(ctx context.Context) (*actions.ActionResult, erro
 		}
 	}
 
-	// Project level hooks
-	projectHooks := hra.projectConfig.Hooks[hookName]
+	publishEvent, closeSink, err := hra.newEventSink()
+	if err != nil {
+		return nil, err
+	}
+	defer closeSink()
+
+	targets, err := hra.collectHookTargets(ctx, hookName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targets) > 0 {
+		nodes := make([]ext.HookNode, 0, len(targets))
+		for _, target := range targets {
+			nodes = append(nodes, target.node)
+		}
 
-	if err := hra.processHooks(
+		plan, err := ext.BuildExecutionPlan(nodes)
+		if err != nil {
+			return nil, fmt.Errorf("resolving hook %s: %w", hookName, err)
+		}
+
+		if err := hra.runPlan(ctx, hookName, plan, targets, publishEvent); err != nil {
+			return nil, err
+		}
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: "Your hooks have been run successfully",
+		},
+	}, nil
+}
+
+// collectHookTargets gathers every project-level and service-level hook registered under
+// hookName into a flat set of execution targets, applying any --platform override up front so
+// the resolved Weight/DependsOn reflect what will actually run.
+func (hra *hooksRunAction) collectHookTargets(ctx context.Context, hookName string) (map[string]*hookTarget, error) {
+	targets := make(map[string]*hookTarget)
+
+	projectHooks := hra.projectConfig.Hooks[hookName]
+	if err := hra.addHookTargets(
 		ctx,
+		targets,
+		"project",
+		"project",
+		"",
 		hra.projectConfig.Path,
-		hookName,
 		fmt.Sprintf("Running %d %s command hook(s) for project", len(projectHooks), hookName),
 		fmt.Sprintf("Project: %s Hook Output", hookName),
+		hookName,
 		projectHooks,
 		false,
 	); err != nil {
@@ -146,17 +254,20 @@ func (hra *hooksRunAction) Run(ctx context.Context) (*actions.ActionResult, erro
 		return nil, err
 	}
 
-	// Service level hooks
 	for _, service := range stableServices {
 		serviceHooks := service.Hooks[hookName]
 		skip := hra.flags.service != "" && service.Name != hra.flags.service
 
-		if err := hra.processHooks(
+		if err := hra.addHookTargets(
 			ctx,
+			targets,
+			service.Name,
+			"service",
+			service.Name,
 			service.RelativePath,
-			hookName,
 			fmt.Sprintf("Running %d %s service hook(s) for %s", len(serviceHooks), hookName, service.Name),
 			fmt.Sprintf("%s: %s hook output", service.Name, hookName),
+			hookName,
 			serviceHooks,
 			skip,
 		); err != nil {
@@ -164,68 +275,232 @@ func (hra *hooksRunAction) Run(ctx context.Context) (*actions.ActionResult, erro
 		}
 	}
 
-	return &actions.ActionResult{
-		Message: &actions.ResultMessage{
-			Header: "Your hooks have been run successfully",
-		},
-	}, nil
+	return targets, nil
 }
 
-// 2. 做更细致的检查
-func (hra *hooksRunAction) processHooks(
+func (hra *hooksRunAction) addHookTargets(
 	ctx context.Context,
+	targets map[string]*hookTarget,
+	idScope string,
+	eventScope string,
+	service string,
 	cwd string,
-	hookName string,
 	spinnerMessage string,
-	previewMessage string,
+	previewTitle string,
+	hookName string,
 	hooks []*ext.HookConfig,
 	skip bool,
 ) error {
-	hra.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	if !hra.jsonToStdout() {
+		hra.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	}
 
-	// 为true跳过
 	if skip {
-		hra.console.StopSpinner(ctx, spinnerMessage, input.StepSkipped)
+		if !hra.jsonToStdout() {
+			hra.console.StopSpinner(ctx, spinnerMessage, input.StepSkipped)
+		}
 		return nil
 	}
 
-	// 查看是否有hooks需要执行
 	if len(hooks) == 0 {
-		hra.console.StopSpinner(ctx, spinnerMessage+noHookFoundMessage, input.StepWarning)
+		if !hra.jsonToStdout() {
+			hra.console.StopSpinner(ctx, spinnerMessage+noHookFoundMessage, input.StepWarning)
+		}
 		return nil
 	}
 
-	// 检查是pre还是post
-	hookType, commandName := ext.InferHookType(hookName)
+	if !hra.jsonToStdout() {
+		hra.console.StopSpinner(ctx, spinnerMessage, input.StepDone)
+	}
 
-	for _, hook := range hooks {
-		// 检查配置项
+	for i, hook := range hooks {
 		if err := hra.prepareHook(hookName, hook); err != nil {
 			return err
 		}
 
-		// 循环执行
-		err := hra.execHook(ctx, previewMessage, cwd, hookType, commandName, hook)
-		if err != nil {
-			hra.console.StopSpinner(ctx, spinnerMessage, input.StepFailed)
-			return fmt.Errorf("failed running hook %s, %w", hookName, err)
+		id := hookNodeID(idScope, i, hook)
+		if _, exists := targets[id]; exists {
+			return fmt.Errorf(
+				"hook '%s' %s: id '%s' is already used by another project or service hook for this event",
+				hookName, idScope, id,
+			)
 		}
 
-		// The previewer cancels the previous spinner so we need to restart/show it again.
-		hra.console.StopSpinner(ctx, spinnerMessage, input.StepDone)
+		targets[id] = &hookTarget{
+			node:         ext.HookNode{ID: id, Hook: hook},
+			cwd:          cwd,
+			scope:        eventScope,
+			service:      service,
+			spinnerTitle: fmt.Sprintf("%s (%s)", spinnerMessage, id),
+			previewTitle: previewTitle,
+		}
 	}
+
 	return nil
 }
 
-// 3. 注入运行时所需要的环境变量
+// hookNodeID returns the identifier other hooks use to depend on hook via DependsOn: its
+// user-settable ext.HookConfig.ID when set, falling back to a position within idScope's list
+// otherwise. The fallback is not stable across edits to the hook list, so a hook meant to be
+// depended on should always set an explicit id.
+func hookNodeID(idScope string, index int, hook *ext.HookConfig) string {
+	if hook.ID != "" {
+		return hook.ID
+	}
+	return fmt.Sprintf("%s#%d", idScope, index)
+}
+
+// jsonToStdout reports whether structured JSON events are being written to stdout, in which
+// case the interactive spinner/previewer UX is suppressed so it doesn't interleave with the
+// event stream.
+func (hra *hooksRunAction) jsonToStdout() bool {
+	return hra.flags.output == "json" && hra.flags.events == ""
+}
+
+// runPlan executes every wave of the plan in order. Hooks within a wave have no unmet
+// dependencies and run concurrently, bounded by --parallel; a hook whose dependency failed is
+// skipped rather than attempted, and every hook already running in a wave finishes before the
+// aggregate error is returned. With --fail-fast (the default), no later wave is started once a
+// wave has a failure; with --fail-fast=false, independent branches in later waves still run.
+func (hra *hooksRunAction) runPlan(
+	ctx context.Context,
+	hookName string,
+	plan []ext.HookWave,
+	targets map[string]*hookTarget,
+	publishEvent func(target *hookTarget, event ext.HookEvent),
+) error {
+	hookType, commandName := ext.InferHookType(hookName)
+	failed := make(map[string]bool)
+	var errs []error
+	var resultMu sync.Mutex
+	// consoleMu serializes every call that touches the console's single-active-indicator state
+	// (the spinner and the previewer both own it, and showing a previewer cancels the active
+	// spinner), so two hooks in the same --parallel wave never clobber each other's display.
+	var consoleMu sync.Mutex
+
+	for _, wave := range plan {
+		sem := make(chan struct{}, hra.parallelism())
+		var wg sync.WaitGroup
+
+		for _, id := range wave {
+			target := targets[id]
+
+			if dep, blocked := hra.blockedByFailure(target.node.Hook.DependsOn, failed); blocked {
+				resultMu.Lock()
+				failed[id] = true
+				errs = append(errs, fmt.Errorf("hook '%s' skipped: dependency '%s' failed", id, dep))
+				resultMu.Unlock()
+				continue
+			}
+
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func(id string, target *hookTarget) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				jsonMode := hra.jsonToStdout()
+				if !jsonMode {
+					consoleMu.Lock()
+					hra.console.ShowSpinner(ctx, target.spinnerTitle, input.Step)
+					consoleMu.Unlock()
+				}
+
+				var onEvent ext.HookEventSink
+				if publishEvent != nil {
+					onEvent = func(event ext.HookEvent) { publishEvent(target, event) }
+				}
+
+				var output bytes.Buffer
+				warned, err := hra.execHook(
+					ctx, target.cwd, hookType, commandName, target.node.Hook, &output, onEvent,
+				)
+
+				if !jsonMode {
+					consoleMu.Lock()
+					hra.flushPreview(ctx, target, output.String())
+					consoleMu.Unlock()
+				}
+
+				if err != nil {
+					if !jsonMode {
+						consoleMu.Lock()
+						hra.console.StopSpinner(ctx, target.spinnerTitle, input.StepFailed)
+						consoleMu.Unlock()
+					}
+
+					resultMu.Lock()
+					failed[id] = true
+					errs = append(errs, fmt.Errorf("failed running hook %s, %w", id, err))
+					resultMu.Unlock()
+
+					return
+				}
+
+				if jsonMode {
+					return
+				}
+
+				consoleMu.Lock()
+				if warned {
+					hra.console.StopSpinner(ctx, target.spinnerTitle, input.StepWarning)
+				} else {
+					hra.console.StopSpinner(ctx, target.spinnerTitle, input.StepDone)
+				}
+				consoleMu.Unlock()
+			}(id, target)
+		}
+
+		wg.Wait()
+
+		if hra.flags.failFast && len(errs) > 0 {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (hra *hooksRunAction) blockedByFailure(dependsOn []string, failed map[string]bool) (string, bool) {
+	for _, dep := range dependsOn {
+		if failed[dep] {
+			return dep, true
+		}
+	}
+	return "", false
+}
+
+func (hra *hooksRunAction) parallelism() int {
+	if hra.flags.parallel < 1 {
+		return 1
+	}
+	return hra.flags.parallel
+}
+
+// flushPreview serializes previewer output per hook so concurrently executed hooks don't
+// interleave their streams.
+func (hra *hooksRunAction) flushPreview(ctx context.Context, target *hookTarget, output string) {
+	previewer := hra.console.ShowPreviewer(ctx, &input.ShowPreviewerOptions{
+		Prefix:       "  ",
+		Title:        target.previewTitle,
+		MaxLineCount: 8,
+	})
+	fmt.Fprint(previewer, output)
+	hra.console.StopPreviewer(ctx, false)
+}
+
+// execHook injects the runtime environment variables and runs a single hook, writing its
+// combined output to out.
 func (hra *hooksRunAction) execHook(
 	ctx context.Context,
-	previewMessage string,
 	cwd string,
 	hookType ext.HookType,
 	commandName string,
 	hook *ext.HookConfig,
-) error {
+	out io.Writer,
+	onEvent ext.HookEventSink,
+) (bool, error) {
 	hookName := string(hookType) + commandName
 
 	hooksMap := map[string][]*ext.HookConfig{
@@ -233,27 +508,12 @@ func (hra *hooksRunAction) execHook(
 	}
 
 	hooksManager := ext.NewHooksManager(cwd)
-	// hra.env为环境变量
-	// &{test-tc-asdhkjh4 map[AZURE_ENV_NAME:test-tc-asdhkjh4] map[] 0xc000284ed0}
-	hooksRunner := ext.NewHooksRunner(hooksManager, hra.commandRunner, hra.envManager, hra.console, cwd, hooksMap, hra.env)
-
-	previewer := hra.console.ShowPreviewer(ctx, &input.ShowPreviewerOptions{
-		Prefix:       "  ",
-		Title:        previewMessage,
-		MaxLineCount: 8,
-	})
-	defer hra.console.StopPreviewer(ctx, false)
+	hooksRunner := ext.NewHooksRunner(
+		hooksManager, hra.commandRunner, hra.envManager, hra.console, cwd, hooksMap, hra.env, hra.flags.offline, onEvent,
+	)
 
-	runOptions := &tools.ExecOptions{StdOut: previewer}
-	// 再次执行
-	// fmt.Println(hookType, '-', commandName, '-', hookName)
-	// post  package  postpackage
-	err := hooksRunner.RunHooks(ctx, hookType, runOptions, commandName)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	runOptions := &tools.ExecOptions{StdOut: out}
+	return hooksRunner.RunHooks(ctx, hookType, runOptions, commandName)
 }
 
 // Overrides the configured hooks from command line flags
@@ -274,8 +534,15 @@ func (hra *hooksRunAction) prepareHook(name string, hook *ext.HookConfig) error
 			} else {
 				*hook = *hook.Posix
 			}
+		case ext.HookPlatformContainer:
+			if hook.Container == nil {
+				return fmt.Errorf("hook is not configured for a container")
+			} else {
+				*hook = *hook.Container
+			}
 		default:
-			return fmt.Errorf("platform %s is not valid. Supported values are windows & posix", hra.flags.platform)
+			return fmt.Errorf(
+				"platform %s is not valid. Supported values are windows, posix & container", hra.flags.platform)
 		}
 	}
 
@@ -285,6 +552,7 @@ func (hra *hooksRunAction) prepareHook(name string, hook *ext.HookConfig) error
 	// Don't display the 'Executing hook...' messages
 	hra.configureHookFlags(hook.Windows)
 	hra.configureHookFlags(hook.Posix)
+	hra.configureHookFlags(hook.Container)
 
 	return nil
 }
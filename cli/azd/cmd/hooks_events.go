@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+)
+
+// hookEventRecord is the JSON shape emitted by `azd hooks run --output json` (or --events),
+// one object per line, for consumption by CI dashboards and log aggregators.
+type hookEventRecord struct {
+	Timestamp  time.Time `json:"ts"`
+	Scope      string    `json:"scope"`
+	Service    string    `json:"service,omitempty"`
+	Hook       string    `json:"hook"`
+	Phase      string    `json:"phase"`
+	Attempt    int       `json:"attempt"`
+	Line       string    `json:"line,omitempty"`
+	ExitCode   *int      `json:"exitCode,omitempty"`
+	DurationMs *int64    `json:"durationMs,omitempty"`
+	Err        string    `json:"err,omitempty"`
+}
+
+// newEventSink returns a publisher for structured hook lifecycle events, plus a func to close
+// its destination, when --output json or --events is set; otherwise the publisher is nil and
+// the close func is a no-op.
+func (hra *hooksRunAction) newEventSink() (func(target *hookTarget, event ext.HookEvent), func() error, error) {
+	noop := func() error { return nil }
+
+	if hra.flags.output != "json" && hra.flags.events == "" {
+		return nil, noop, nil
+	}
+
+	out := os.Stdout
+	closeSink := noop
+
+	if hra.flags.events != "" {
+		f, err := os.Create(hra.flags.events)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating events file: %w", err)
+		}
+
+		out = f
+		closeSink = f.Close
+	}
+
+	encoder := json.NewEncoder(out)
+	var encoderMu sync.Mutex
+
+	publish := func(target *hookTarget, event ext.HookEvent) {
+		record := hookEventRecord{
+			Timestamp: event.Timestamp,
+			Scope:     target.scope,
+			Service:   target.service,
+			Hook:      event.Hook,
+			Phase:     string(event.Phase),
+			Attempt:   event.Attempt,
+			Line:      event.Line,
+		}
+
+		if event.Phase == ext.HookEventExit {
+			exitCode := event.ExitCode
+			durationMs := event.Duration.Milliseconds()
+			record.ExitCode = &exitCode
+			record.DurationMs = &durationMs
+
+			if event.Err != nil {
+				record.Err = event.Err.Error()
+			}
+		}
+
+		// runPlan invokes publish concurrently from every hook running in the same wave;
+		// json.Encoder isn't safe for concurrent use, so serialize writes the same way
+		// runPlan serializes console and previewer output via consoleMu.
+		encoderMu.Lock()
+		defer encoderMu.Unlock()
+		_ = encoder.Encode(record)
+	}
+
+	return publish, closeSink, nil
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func newHooksListFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *hooksListFlags {
+	flags := &hooksListFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newHooksListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the hooks configured for the project and its services",
+	}
+}
+
+type hooksListFlags struct {
+	global  *internal.GlobalCommandOptions
+	service string
+	event   string
+	output  string
+}
+
+func (f *hooksListFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.global = global
+
+	local.StringVar(&f.service, "service", "", "Only lists hooks for the specified service.")
+	local.StringVar(&f.event, "event", "", "Only lists hooks registered for the specified event, e.g. predeploy.")
+	local.StringVar(&f.output, "output", "", "The output format to use. Allowed values: json.")
+}
+
+// hookInfo describes a single resolved hook for display by `azd hooks list`.
+type hookInfo struct {
+	Scope     string   `json:"scope"`
+	Service   string   `json:"service,omitempty"`
+	Event     string   `json:"event"`
+	Type      string   `json:"type"`
+	Command   string   `json:"command"`
+	Platforms []string `json:"platforms"`
+	// Source is the project or service path the hook was declared under. It does not include a
+	// line number: no azure.yaml loader in this tree attributes hook entries to a source line,
+	// so "source file/line" is only partially delivered today.
+	Source string `json:"source"`
+}
+
+type hooksListAction struct {
+	projectConfig *project.ProjectConfig
+	importManager *project.ImportManager
+	console       input.Console
+	flags         *hooksListFlags
+}
+
+func newHooksListAction(
+	projectConfig *project.ProjectConfig,
+	importManager *project.ImportManager,
+	console input.Console,
+	flags *hooksListFlags,
+) actions.Action {
+	return &hooksListAction{
+		projectConfig: projectConfig,
+		importManager: importManager,
+		console:       console,
+		flags:         flags,
+	}
+}
+
+func (hla *hooksListAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if hla.flags.service != "" {
+		if has, err := hla.importManager.HasService(ctx, hla.projectConfig, hla.flags.service); err != nil {
+			return nil, err
+		} else if !has {
+			return nil, fmt.Errorf("service name '%s' doesn't exist", hla.flags.service)
+		}
+	}
+
+	var hooks []hookInfo
+	hooks = append(
+		hooks,
+		collectHookInfo("project", "", hla.projectConfig.Path, hla.projectConfig.Hooks, hla.flags.event)...,
+	)
+
+	stableServices, err := hla.importManager.ServiceStable(ctx, hla.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, service := range stableServices {
+		if hla.flags.service != "" && service.Name != hla.flags.service {
+			continue
+		}
+
+		hooks = append(
+			hooks,
+			collectHookInfo("service", service.Name, service.RelativePath, service.Hooks, hla.flags.event)...,
+		)
+	}
+
+	sort.SliceStable(hooks, func(i, j int) bool {
+		if hooks[i].Scope != hooks[j].Scope {
+			return hooks[i].Scope < hooks[j].Scope
+		}
+		if hooks[i].Service != hooks[j].Service {
+			return hooks[i].Service < hooks[j].Service
+		}
+		return hooks[i].Event < hooks[j].Event
+	})
+
+	if hla.flags.output == "json" {
+		contents, err := json.MarshalIndent(hooks, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshalling hooks: %w", err)
+		}
+
+		hla.console.Message(ctx, string(contents))
+		return nil, nil
+	}
+
+	if len(hooks) == 0 {
+		hla.console.Message(ctx, "No hooks are configured for this project.")
+		return nil, nil
+	}
+
+	for _, hook := range hooks {
+		scope := hook.Scope
+		if hook.Service != "" {
+			scope = fmt.Sprintf("%s (%s)", hook.Scope, hook.Service)
+		}
+
+		hla.console.Message(ctx, fmt.Sprintf(
+			"%-6s %-24s %-18s %-40s %s",
+			hook.Type, scope, hook.Event, hook.Command, hook.Source,
+		))
+	}
+
+	return nil, nil
+}
+
+// collectHookInfo flattens hooks into hookInfo, optionally filtered to a single event name.
+func collectHookInfo(
+	scope string,
+	service string,
+	source string,
+	hooks map[string][]*ext.HookConfig,
+	event string,
+) []hookInfo {
+	var infos []hookInfo
+
+	for name, hookList := range hooks {
+		if event != "" && name != event {
+			continue
+		}
+
+		hookType, _ := ext.InferHookType(name)
+
+		for _, hook := range hookList {
+			infos = append(infos, hookInfo{
+				Scope:     scope,
+				Service:   service,
+				Event:     name,
+				Type:      string(hookType),
+				Command:   hookCommand(hook),
+				Platforms: hookPlatforms(hook),
+				Source:    source,
+			})
+		}
+	}
+
+	return infos
+}
+
+func hookCommand(hook *ext.HookConfig) string {
+	switch {
+	case hook.Image != "":
+		return fmt.Sprintf("image: %s", hook.Image)
+	case hook.Url != "":
+		return fmt.Sprintf("url: %s", hook.Url)
+	case hook.Run != "":
+		return hook.Run
+	case hook.Windows != nil || hook.Posix != nil || hook.Container != nil:
+		return "(platform specific)"
+	default:
+		return ""
+	}
+}
+
+func hookPlatforms(hook *ext.HookConfig) []string {
+	var platforms []string
+
+	if hook.Run != "" || hook.Shell != "" {
+		platforms = append(platforms, "host")
+	}
+	if hook.Windows != nil {
+		platforms = append(platforms, string(ext.HookPlatformWindows))
+	}
+	if hook.Posix != nil {
+		platforms = append(platforms, string(ext.HookPlatformPosix))
+	}
+	if hook.HasContainer() {
+		platforms = append(platforms, string(ext.HookPlatformContainer))
+	}
+	if len(platforms) == 0 {
+		platforms = append(platforms, "host")
+	}
+
+	return platforms
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+)
+
+func TestHookCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		hook *ext.HookConfig
+		want string
+	}{
+		{name: "image takes priority", hook: &ext.HookConfig{Image: "alpine", Run: "echo hi"}, want: "image: alpine"},
+		{name: "url over run", hook: &ext.HookConfig{Url: "https://example.com/s.sh", Run: "echo hi"}, want: "url: https://example.com/s.sh"},
+		{name: "a plain run command", hook: &ext.HookConfig{Run: "echo hi"}, want: "echo hi"},
+		{
+			name: "platform overrides with no base run command",
+			hook: &ext.HookConfig{Windows: &ext.HookConfig{Run: "dir"}, Posix: &ext.HookConfig{Run: "ls"}},
+			want: "(platform specific)",
+		},
+		{name: "nothing configured", hook: &ext.HookConfig{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hookCommand(tt.hook); got != tt.want {
+				t.Errorf("hookCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHookPlatforms(t *testing.T) {
+	tests := []struct {
+		name string
+		hook *ext.HookConfig
+		want []string
+	}{
+		{name: "a run command is host", hook: &ext.HookConfig{Run: "echo hi"}, want: []string{"host"}},
+		{
+			name: "every platform can be listed at once",
+			hook: &ext.HookConfig{
+				Run:     "echo hi",
+				Windows: &ext.HookConfig{Run: "dir"},
+				Posix:   &ext.HookConfig{Run: "ls"},
+				Image:   "alpine",
+			},
+			want: []string{"host", "windows", "posix", "container"},
+		},
+		{name: "nothing configured defaults to host", hook: &ext.HookConfig{}, want: []string{"host"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hookPlatforms(tt.hook)
+			if len(got) != len(tt.want) {
+				t.Fatalf("hookPlatforms() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("hookPlatforms() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectHookInfo(t *testing.T) {
+	hooks := map[string][]*ext.HookConfig{
+		"predeploy":  {{Run: "echo pre"}},
+		"postdeploy": {{Run: "echo post"}},
+	}
+
+	t.Run("collects every event when unfiltered", func(t *testing.T) {
+		infos := collectHookInfo("project", "", "./", hooks, "")
+		if len(infos) != 2 {
+			t.Fatalf("expected 2 hookInfo entries, got %d", len(infos))
+		}
+	})
+
+	t.Run("filters to a single event", func(t *testing.T) {
+		infos := collectHookInfo("project", "", "./", hooks, "predeploy")
+		if len(infos) != 1 || infos[0].Event != "predeploy" {
+			t.Fatalf("expected only the predeploy hook, got %v", infos)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func newHooksValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the hooks configured for the project without running them",
+	}
+}
+
+type hooksValidateAction struct {
+	projectConfig *project.ProjectConfig
+	importManager *project.ImportManager
+	commandRunner exec.CommandRunner
+	console       input.Console
+}
+
+func newHooksValidateAction(
+	projectConfig *project.ProjectConfig,
+	importManager *project.ImportManager,
+	commandRunner exec.CommandRunner,
+	console input.Console,
+) actions.Action {
+	return &hooksValidateAction{
+		projectConfig: projectConfig,
+		importManager: importManager,
+		commandRunner: commandRunner,
+		console:       console,
+	}
+}
+
+// Run validates every project and service hook without executing any of them: it checks that
+// the configured shell exists on PATH, that a hook has a command for at least one of host,
+// platform override, or container, that a hook overriding only one of windows/posix has a base
+// command or container image to fall back to on the other, and that every dependsOn target
+// exists, is unique, and is free of cycles, using the same hook IDs `azd hooks run` would use to
+// build its execution plan.
+func (hva *hooksValidateAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	stableServices, err := hva.importManager.ServiceStable(ctx, hva.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	events := map[string]bool{}
+	for name := range hva.projectConfig.Hooks {
+		events[name] = true
+	}
+	for _, service := range stableServices {
+		for name := range service.Hooks {
+			events[name] = true
+		}
+	}
+
+	var problems []string
+
+	for event := range events {
+		var nodes []ext.HookNode
+
+		for i, hook := range hva.projectConfig.Hooks[event] {
+			nodes = append(nodes, ext.HookNode{ID: hookNodeID("project", i, hook), Hook: hook})
+			problems = append(problems, hva.validateHook("project", event, hook)...)
+		}
+
+		for _, service := range stableServices {
+			for i, hook := range service.Hooks[event] {
+				nodes = append(nodes, ext.HookNode{ID: hookNodeID(service.Name, i, hook), Hook: hook})
+				problems = append(problems, hva.validateHook(service.Name, event, hook)...)
+			}
+		}
+
+		if _, err := ext.BuildExecutionPlan(nodes); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", event, err))
+		}
+	}
+
+	if len(problems) == 0 {
+		hva.console.Message(ctx, "No problems found.")
+		return &actions.ActionResult{
+			Message: &actions.ResultMessage{Header: "Your hooks are valid"},
+		}, nil
+	}
+
+	for _, problem := range problems {
+		hva.console.Message(ctx, fmt.Sprintf("- %s", problem))
+	}
+
+	return nil, fmt.Errorf("found %d problem(s) with the configured hooks", len(problems))
+}
+
+func (hva *hooksValidateAction) validateHook(scope string, event string, hook *ext.HookConfig) []string {
+	var problems []string
+
+	hasHost := hook.Run != "" || hook.Url != ""
+	hasPlatform := hook.Windows != nil || hook.Posix != nil
+	hasContainer := hook.HasContainer()
+
+	if !hasHost && !hasPlatform && !hasContainer {
+		problems = append(problems, fmt.Sprintf(
+			"%s %s: hook has no run command, windows/posix override, or container image", scope, event,
+		))
+	}
+
+	if (hook.Windows != nil) != (hook.Posix != nil) && !hasHost && !hasContainer {
+		missing := ext.HookPlatformPosix
+		if hook.Posix != nil {
+			missing = ext.HookPlatformWindows
+		}
+		problems = append(problems, fmt.Sprintf(
+			"%s %s: hook only overrides one of windows/posix (missing %s) and has no base run "+
+				"command or container image to fall back to, so it will fail at runtime on that platform",
+			scope, event, missing,
+		))
+	}
+
+	if hook.Url != "" && hook.Sha256 == "" {
+		problems = append(problems, fmt.Sprintf(
+			"%s %s: hook references a url but has no required sha256 digest", scope, event,
+		))
+	}
+
+	if hasHost && hook.Shell != "" {
+		if _, err := hva.commandRunner.LookPath(hook.Shell); err != nil {
+			problems = append(problems, fmt.Sprintf(
+				"%s %s: shell '%s' was not found on PATH", scope, event, hook.Shell,
+			))
+		}
+	}
+
+	for _, dep := range hook.DependsOn {
+		if dep == "" {
+			problems = append(problems, fmt.Sprintf("%s %s: dependsOn entry is empty", scope, event))
+		}
+	}
+
+	return problems
+}
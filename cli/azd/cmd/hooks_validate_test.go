@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+)
+
+type fakeValidateCommandRunner struct {
+	lookPathErr map[string]error
+}
+
+func (f *fakeValidateCommandRunner) LookPath(file string) (string, error) {
+	if err, ok := f.lookPathErr[file]; ok {
+		return "", err
+	}
+	return "/usr/bin/" + file, nil
+}
+
+func (f *fakeValidateCommandRunner) Run(ctx context.Context, args exec.RunArgs) (exec.RunResult, error) {
+	return exec.RunResult{}, nil
+}
+
+func TestValidateHook(t *testing.T) {
+	hva := &hooksValidateAction{commandRunner: &fakeValidateCommandRunner{}}
+
+	tests := []struct {
+		name        string
+		hook        *ext.HookConfig
+		wantProblem string
+	}{
+		{
+			name:        "a run command alone is valid",
+			hook:        &ext.HookConfig{Run: "echo hi"},
+			wantProblem: "",
+		},
+		{
+			name:        "no command at all is a problem",
+			hook:        &ext.HookConfig{},
+			wantProblem: "hook has no run command, windows/posix override, or container image",
+		},
+		{
+			name: "windows only, with no base run command, is a problem",
+			hook: &ext.HookConfig{
+				Windows: &ext.HookConfig{Run: "dir"},
+			},
+			wantProblem: "only overrides one of windows/posix",
+		},
+		{
+			name: "windows and posix together, with no base run command, is valid",
+			hook: &ext.HookConfig{
+				Windows: &ext.HookConfig{Run: "dir"},
+				Posix:   &ext.HookConfig{Run: "ls"},
+			},
+			wantProblem: "",
+		},
+		{
+			name: "windows only is valid when a base run command covers the other platform",
+			hook: &ext.HookConfig{
+				Run:     "echo hi",
+				Windows: &ext.HookConfig{Run: "dir"},
+			},
+			wantProblem: "",
+		},
+		{
+			name: "windows only is valid when a container image covers the other platform",
+			hook: &ext.HookConfig{
+				Image:   "alpine",
+				Windows: &ext.HookConfig{Run: "dir"},
+			},
+			wantProblem: "",
+		},
+		{
+			name:        "a url without a sha256 digest is a problem",
+			hook:        &ext.HookConfig{Url: "https://example.com/script.sh"},
+			wantProblem: "hook references a url but has no required sha256 digest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := hva.validateHook("project", "predeploy", tt.hook)
+
+			if tt.wantProblem == "" {
+				if len(problems) != 0 {
+					t.Fatalf("expected no problems, got %v", problems)
+				}
+				return
+			}
+
+			if len(problems) == 0 {
+				t.Fatalf("expected a problem containing %q, got none", tt.wantProblem)
+			}
+
+			found := false
+			for _, p := range problems {
+				if strings.Contains(p, tt.wantProblem) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected a problem containing %q, got %v", tt.wantProblem, problems)
+			}
+		})
+	}
+
+	t.Run("a shell missing from PATH is a problem", func(t *testing.T) {
+		hva := &hooksValidateAction{
+			commandRunner: &fakeValidateCommandRunner{
+				lookPathErr: map[string]error{"pwsh": context.DeadlineExceeded},
+			},
+		}
+
+		problems := hva.validateHook("project", "predeploy", &ext.HookConfig{Run: "echo hi", Shell: "pwsh"})
+		if len(problems) != 1 || !strings.Contains(problems[0], "shell 'pwsh' was not found on PATH") {
+			t.Fatalf("expected a missing shell problem, got %v", problems)
+		}
+	})
+}
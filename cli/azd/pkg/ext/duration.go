@@ -0,0 +1,31 @@
+package ext
+
+import (
+	"fmt"
+	"time"
+)
+
+// Duration is a time.Duration that unmarshals from the short human-readable strings used
+// throughout azure.yaml, e.g. "30s" or "2m".
+type Duration time.Duration
+
+// Duration returns the underlying time.Duration.
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// UnmarshalYAML parses a duration string such as "30s" into the underlying time.Duration.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw string
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", raw, err)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
@@ -0,0 +1,65 @@
+package ext
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "seconds", raw: "30s", want: 30 * time.Second},
+		{name: "minutes", raw: "2m", want: 2 * time.Minute},
+		{name: "combined units", raw: "1h30m", want: 90 * time.Minute},
+		{name: "empty string is invalid", raw: "", wantErr: true},
+		{name: "unitless number is invalid", raw: "5", wantErr: true},
+		{name: "garbage is invalid", raw: "not-a-duration", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+
+			err := d.UnmarshalYAML(func(out interface{}) error {
+				*out.(*string) = tt.raw
+				return nil
+			})
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q, got none", tt.raw)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if d.Duration() != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, d.Duration())
+			}
+		})
+	}
+}
+
+func TestDurationUnmarshalYAMLPropagatesUnmarshalError(t *testing.T) {
+	var d Duration
+
+	wantErr := testErr("boom")
+	err := d.UnmarshalYAML(func(out interface{}) error {
+		return wantErr
+	})
+
+	if err != wantErr {
+		t.Fatalf("expected the unmarshal func's error to propagate, got %v", err)
+	}
+}
+
+type testErr string
+
+func (e testErr) Error() string { return string(e) }
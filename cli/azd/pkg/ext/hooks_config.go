@@ -0,0 +1,107 @@
+package ext
+
+import "strings"
+
+// HookType represents the timing of a hook relative to the command it decorates, e.g.
+// "pre" for a hook that runs before "deploy" in "predeploy".
+type HookType string
+
+const (
+	HookTypePre  HookType = "pre"
+	HookTypePost HookType = "post"
+)
+
+// HookPlatformType scopes a hook override to a specific execution environment.
+type HookPlatformType string
+
+const (
+	HookPlatformWindows   HookPlatformType = "windows"
+	HookPlatformPosix     HookPlatformType = "posix"
+	HookPlatformContainer HookPlatformType = "container"
+)
+
+// InferHookType splits a hook name such as "predeploy" into its HookType and the
+// underlying command name, e.g. "predeploy" -> (HookTypePre, "deploy").
+func InferHookType(name string) (HookType, string) {
+	switch {
+	case strings.HasPrefix(name, string(HookTypePre)):
+		return HookTypePre, strings.TrimPrefix(name, string(HookTypePre))
+	case strings.HasPrefix(name, string(HookTypePost)):
+		return HookTypePost, strings.TrimPrefix(name, string(HookTypePost))
+	default:
+		return HookTypePre, name
+	}
+}
+
+// HookConfig describes a single hook command and its optional per-platform overrides.
+type HookConfig struct {
+	// Name is the fully qualified hook name, e.g. "predeploy". Populated at resolution
+	// time and not read from azure.yaml.
+	Name string `yaml:"-"`
+	// ID is a user-chosen, stable identifier for this hook entry, unique among every project
+	// and service hook registered under the same hook name. Set it to give DependsOn something
+	// durable to reference; a hook that isn't depended on doesn't need one.
+	ID string `yaml:"id,omitempty"`
+	// Shell is the interpreter used to run Run, e.g. "sh" or "pwsh".
+	Shell string `yaml:"shell,omitempty"`
+	// Run is the inline script or path to a script file to execute.
+	Run string `yaml:"run"`
+	// Interactive indicates whether the hook should inherit the parent's stdio.
+	Interactive bool `yaml:"interactive,omitempty"`
+
+	// Weight orders execution relative to other hooks registered under the same hook name,
+	// lower values running first. Hooks sharing a weight and with no unmet DependsOn run
+	// concurrently.
+	Weight int `yaml:"weight,omitempty"`
+	// DependsOn lists the ID of other hooks that must complete successfully before this one
+	// is eligible to run. Refers to the target hook's ID; a hook with no ID set can't be
+	// depended on.
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+
+	// ContinueOnError lets the hooks run proceed past this hook's final failed attempt,
+	// marking the step as a warning instead of aborting.
+	ContinueOnError bool `yaml:"continueOnError,omitempty"`
+	// Retries is the number of additional attempts made after an initial failure or timeout.
+	Retries int `yaml:"retries,omitempty"`
+	// RetryDelay is the backoff before the first retry; it doubles after each subsequent
+	// failed attempt. Defaults to one second when Retries is set but RetryDelay is not.
+	RetryDelay Duration `yaml:"retryDelay,omitempty"`
+	// Timeout bounds how long a single attempt may run before it is treated as a failure.
+	Timeout Duration `yaml:"timeout,omitempty"`
+
+	// Url references a remote script to run instead of Run, as an http(s) URL. A required
+	// Sha256 digest is verified before the script is ever executed. OCI/registry references
+	// are not supported yet; resolveRemoteScript rejects them explicitly rather than silently
+	// mishandling them.
+	Url string `yaml:"url,omitempty"`
+	// Sha256 is the required digest of the script fetched from Url. Execution is refused if
+	// the downloaded (or cached) script doesn't match. Because the cache is keyed by this
+	// digest, a cached script matching it is always reused instead of re-downloading.
+	Sha256 string `yaml:"sha256,omitempty"`
+
+	// Image is the container image the hook runs in. When set, the hook executes via the
+	// configured container runtime instead of the host shell.
+	Image string `yaml:"image,omitempty"`
+	// Entrypoint overrides the image's entrypoint. When empty, the image's own entrypoint is
+	// kept. In both cases a set Run is passed as the command, as `<Shell or sh> -c <Run>`.
+	Entrypoint string `yaml:"entrypoint,omitempty"`
+	// WorkDir overrides the in-container working directory. Defaults to the mounted
+	// project directory.
+	WorkDir string `yaml:"workdir,omitempty"`
+	// Volumes lists additional `<host>:<container>` bind mounts beyond the project
+	// directory, which is always mounted.
+	Volumes []string `yaml:"volumes,omitempty"`
+
+	// Windows overrides the hook when running on Windows.
+	Windows *HookConfig `yaml:"windows,omitempty"`
+	// Posix overrides the hook when running on a Posix platform.
+	Posix *HookConfig `yaml:"posix,omitempty"`
+	// Container overrides the hook when run via a container runtime, letting a project
+	// stay portable across developer machines without requiring bash or PowerShell locally.
+	Container *HookConfig `yaml:"container,omitempty"`
+}
+
+// HasContainer reports whether the hook should be executed inside a container.
+func (hc *HookConfig) HasContainer() bool {
+	return hc.Image != "" || (hc.Container != nil && hc.Container.Image != "")
+}
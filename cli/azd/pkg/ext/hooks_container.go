@@ -0,0 +1,94 @@
+package ext
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// containerRuntimes are searched, in order, for a usable container CLI. The first one found
+// on PATH is used for every hook in the run.
+var containerRuntimes = []string{"docker", "podman"}
+
+// runContainerHook executes hook inside a container instead of the host shell. The project
+// directory is always bind mounted so the hook can see (and emit) project files, and the
+// resolved azd environment variables are propagated as container env so a container hook
+// behaves the same as a host hook.
+func (r *HooksRunner) runContainerHook(ctx context.Context, hook *HookConfig, options *tools.ExecOptions) error {
+	container := hook
+	if hook.Container != nil {
+		container = hook.Container
+	}
+
+	if container.Image == "" {
+		return fmt.Errorf("hook '%s' has no container image configured", hook.Name)
+	}
+
+	runtime, err := r.resolveContainerRuntime(ctx)
+	if err != nil {
+		return err
+	}
+
+	workDir := container.WorkDir
+	if workDir == "" {
+		workDir = "/workspace"
+	}
+
+	args := []string{
+		"run", "--rm",
+		"-v", fmt.Sprintf("%s:%s", r.cwd, workDir),
+		"-w", workDir,
+	}
+
+	for _, volume := range container.Volumes {
+		args = append(args, "-v", volume)
+	}
+
+	for _, kv := range r.env.Environ() {
+		args = append(args, "-e", kv)
+	}
+
+	if container.Entrypoint != "" {
+		args = append(args, "--entrypoint", container.Entrypoint)
+	}
+
+	args = append(args, container.Image)
+
+	if container.Run != "" {
+		shell := container.Shell
+		if shell == "" {
+			shell = "sh"
+		}
+		args = append(args, shell, "-c", container.Run)
+	}
+
+	runArgs := exec.NewRunArgs(runtime, args...).WithCwd(r.cwd)
+	if options != nil {
+		runArgs = runArgs.WithStdOut(options.StdOut)
+	}
+
+	res, err := r.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed running hook '%s' in container: %w", hook.Name, err)
+	}
+
+	if res.ExitCode != 0 {
+		return fmt.Errorf("hook '%s' exited with code %d", hook.Name, res.ExitCode)
+	}
+
+	return nil
+}
+
+// resolveContainerRuntime returns the first available container CLI on PATH.
+func (r *HooksRunner) resolveContainerRuntime(ctx context.Context) (string, error) {
+	for _, runtime := range containerRuntimes {
+		if _, err := r.commandRunner.LookPath(runtime); err == nil {
+			return runtime, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"hook requires a container runtime but none of %v were found on PATH", containerRuntimes)
+}
@@ -0,0 +1,147 @@
+package ext
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// fakeCommandRunner is a minimal exec.CommandRunner double for exercising hooks_container.go
+// without shelling out to a real container runtime.
+type fakeCommandRunner struct {
+	lookPath func(file string) (string, error)
+	run      func(ctx context.Context, args exec.RunArgs) (exec.RunResult, error)
+}
+
+func (f *fakeCommandRunner) LookPath(file string) (string, error) {
+	return f.lookPath(file)
+}
+
+func (f *fakeCommandRunner) Run(ctx context.Context, args exec.RunArgs) (exec.RunResult, error) {
+	return f.run(ctx, args)
+}
+
+func TestResolveContainerRuntime(t *testing.T) {
+	t.Run("prefers docker over podman when both are on PATH", func(t *testing.T) {
+		r := &HooksRunner{commandRunner: &fakeCommandRunner{
+			lookPath: func(file string) (string, error) { return "/usr/bin/" + file, nil },
+		}}
+
+		runtime, err := r.resolveContainerRuntime(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if runtime != "docker" {
+			t.Errorf("expected docker, got %s", runtime)
+		}
+	})
+
+	t.Run("falls back to podman when docker isn't found", func(t *testing.T) {
+		r := &HooksRunner{commandRunner: &fakeCommandRunner{
+			lookPath: func(file string) (string, error) {
+				if file == "docker" {
+					return "", errNotFound
+				}
+				return "/usr/bin/" + file, nil
+			},
+		}}
+
+		runtime, err := r.resolveContainerRuntime(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if runtime != "podman" {
+			t.Errorf("expected podman, got %s", runtime)
+		}
+	})
+
+	t.Run("errors when no runtime is found", func(t *testing.T) {
+		r := &HooksRunner{commandRunner: &fakeCommandRunner{
+			lookPath: func(file string) (string, error) { return "", errNotFound },
+		}}
+
+		if _, err := r.resolveContainerRuntime(context.Background()); err == nil {
+			t.Fatal("expected an error when no container runtime is on PATH")
+		}
+	})
+}
+
+func TestRunContainerHook(t *testing.T) {
+	env := &environment.Environment{}
+
+	t.Run("errors when the hook has no image", func(t *testing.T) {
+		r := &HooksRunner{env: env}
+		err := r.runContainerHook(context.Background(), &HookConfig{Name: "predeploy"}, nil)
+		if err == nil || !strings.Contains(err.Error(), "no container image configured") {
+			t.Fatalf("expected a missing image error, got %v", err)
+		}
+	})
+
+	t.Run("errors when no container runtime is available", func(t *testing.T) {
+		r := &HooksRunner{env: env, commandRunner: &fakeCommandRunner{
+			lookPath: func(file string) (string, error) { return "", errNotFound },
+		}}
+		err := r.runContainerHook(context.Background(), &HookConfig{Name: "predeploy", Image: "alpine"}, nil)
+		if err == nil || !strings.Contains(err.Error(), "container runtime") {
+			t.Fatalf("expected a container runtime error, got %v", err)
+		}
+	})
+
+	t.Run("wraps a nonzero exit code as an error", func(t *testing.T) {
+		r := &HooksRunner{env: env, cwd: "/proj", commandRunner: &fakeCommandRunner{
+			lookPath: func(file string) (string, error) { return "/usr/bin/" + file, nil },
+			run: func(ctx context.Context, args exec.RunArgs) (exec.RunResult, error) {
+				return exec.RunResult{ExitCode: 1}, nil
+			},
+		}}
+		err := r.runContainerHook(context.Background(), &HookConfig{Name: "predeploy", Image: "alpine"}, nil)
+		if err == nil || !strings.Contains(err.Error(), "exited with code 1") {
+			t.Fatalf("expected an exit code error, got %v", err)
+		}
+	})
+
+	t.Run("succeeds when the container exits zero", func(t *testing.T) {
+		r := &HooksRunner{env: env, cwd: "/proj", commandRunner: &fakeCommandRunner{
+			lookPath: func(file string) (string, error) { return "/usr/bin/" + file, nil },
+			run: func(ctx context.Context, args exec.RunArgs) (exec.RunResult, error) {
+				return exec.RunResult{ExitCode: 0}, nil
+			},
+		}}
+		if err := r.runContainerHook(
+			context.Background(), &HookConfig{Name: "predeploy", Image: "alpine"}, &tools.ExecOptions{},
+		); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("uses the Container override's image and run command over the top-level hook", func(t *testing.T) {
+		r := &HooksRunner{env: env, cwd: "/proj", commandRunner: &fakeCommandRunner{
+			lookPath: func(file string) (string, error) { return "/usr/bin/" + file, nil },
+			run: func(ctx context.Context, args exec.RunArgs) (exec.RunResult, error) {
+				return exec.RunResult{ExitCode: 0}, nil
+			},
+		}}
+
+		hook := &HookConfig{
+			Name: "predeploy",
+			Container: &HookConfig{
+				Image: "alpine",
+				Run:   "echo hi",
+			},
+		}
+
+		if err := r.runContainerHook(context.Background(), hook, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+type notFoundErr string
+
+func (e notFoundErr) Error() string { return string(e) }
+
+var errNotFound = notFoundErr("not found")
@@ -0,0 +1,94 @@
+package ext
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// HookEventPhase is a lifecycle transition emitted while a hook runs. Only the hook's stdout is
+// captured line-by-line today; there's no HookEventStderr until stderr is wired through
+// separately from the combined preview/log stream.
+type HookEventPhase string
+
+const (
+	HookEventStart  HookEventPhase = "start"
+	HookEventStdout HookEventPhase = "stdout"
+	HookEventExit   HookEventPhase = "exit"
+)
+
+// HookEvent is a single lifecycle transition for a hook execution attempt. It drives both the
+// interactive console UX and structured sinks like `azd hooks run --output json`.
+type HookEvent struct {
+	Timestamp time.Time
+	Hook      string
+	Phase     HookEventPhase
+	Attempt   int
+	Line      string
+	ExitCode  int
+	Duration  time.Duration
+	Err       error
+}
+
+// HookEventSink receives HookEvents as a hook runs. Publish must not block the hook.
+type HookEventSink func(HookEvent)
+
+func (r *HooksRunner) publish(event HookEvent) {
+	if r.onEvent == nil {
+		return
+	}
+
+	event.Timestamp = time.Now()
+	r.onEvent(event)
+}
+
+// wrapOptionsForEvents returns options with StdOut replaced by a writer that still forwards to
+// the original StdOut (the previewer) but additionally publishes a HookEventStdout event per
+// line written, so a JSON event sink observes the same output stream as the interactive UX.
+func (r *HooksRunner) wrapOptionsForEvents(hook *HookConfig, attempt int, options *tools.ExecOptions) *tools.ExecOptions {
+	if options == nil || r.onEvent == nil {
+		return options
+	}
+
+	wrapped := *options
+	wrapped.StdOut = &lineEventWriter{
+		underlying: options.StdOut,
+		publish: func(line string) {
+			r.publish(HookEvent{Hook: hook.Name, Phase: HookEventStdout, Attempt: attempt, Line: line})
+		},
+	}
+
+	return &wrapped
+}
+
+// lineEventWriter forwards every write to underlying unchanged while additionally publishing
+// one event per completed line.
+type lineEventWriter struct {
+	underlying io.Writer
+	publish    func(line string)
+	buf        []byte
+}
+
+func (w *lineEventWriter) Write(p []byte) (int, error) {
+	if w.underlying != nil {
+		if _, err := w.underlying.Write(p); err != nil {
+			return 0, err
+		}
+	}
+
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+
+		line := string(w.buf[:idx])
+		w.buf = w.buf[idx+1:]
+		w.publish(line)
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,66 @@
+package ext
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLineEventWriterPublishesOneEventPerCompletedLine(t *testing.T) {
+	var underlying bytes.Buffer
+	var lines []string
+
+	w := &lineEventWriter{
+		underlying: &underlying,
+		publish: func(line string) {
+			lines = append(lines, line)
+		},
+	}
+
+	if _, err := w.Write([]byte("first\nsec")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := w.Write([]byte("ond\nthird")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := lines, []string{"first", "second"}; !stringSlicesEqual(got, want) {
+		t.Errorf("expected completed lines %v, got %v", want, got)
+	}
+
+	if got, want := underlying.String(), "first\nsecond\nthird"; got != want {
+		t.Errorf("expected underlying to receive every byte unchanged, got %q, want %q", got, want)
+	}
+}
+
+func TestPublishIsANoOpWithoutAnEventSink(t *testing.T) {
+	r := &HooksRunner{}
+
+	// onEvent is nil; publish must not panic.
+	r.publish(HookEvent{Hook: "predeploy", Phase: HookEventStart})
+}
+
+func TestPublishStampsATimestampAndForwardsToOnEvent(t *testing.T) {
+	var got HookEvent
+	r := &HooksRunner{onEvent: func(event HookEvent) { got = event }}
+
+	r.publish(HookEvent{Hook: "predeploy", Phase: HookEventExit, ExitCode: 1})
+
+	if got.Hook != "predeploy" || got.Phase != HookEventExit || got.ExitCode != 1 {
+		t.Errorf("expected the event fields to pass through unchanged, got %+v", got)
+	}
+	if got.Timestamp.IsZero() {
+		t.Error("expected publish to stamp a non-zero Timestamp")
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,12 @@
+package ext
+
+// HooksManager holds the working directory that hook configuration is resolved relative to.
+type HooksManager struct {
+	cwd string
+}
+
+// NewHooksManager creates a HooksManager rooted at cwd, the project or service path the
+// hooks should be executed from.
+func NewHooksManager(cwd string) *HooksManager {
+	return &HooksManager{cwd: cwd}
+}
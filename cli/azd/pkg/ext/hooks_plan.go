@@ -0,0 +1,90 @@
+package ext
+
+import "fmt"
+
+// HookNode pairs a HookConfig with the stable identifier other hooks use to depend on it
+// via DependsOn.
+type HookNode struct {
+	ID   string
+	Hook *HookConfig
+}
+
+// HookWave is a set of hook IDs with no unmet dependencies among themselves and sharing the
+// lowest remaining Weight, making them eligible to run concurrently.
+type HookWave []string
+
+// BuildExecutionPlan turns a flat set of hooks into an ordered list of waves. Hooks are
+// released in topological order of DependsOn, breaking ties by ascending Weight (lower
+// weight runs first, mirroring Helm's hook-weight model); hooks that share a wave have every
+// dependency already satisfied by an earlier wave and may run concurrently.
+func BuildExecutionPlan(nodes []HookNode) ([]HookWave, error) {
+	byID := make(map[string]HookNode, len(nodes))
+	for _, n := range nodes {
+		if _, ok := byID[n.ID]; ok {
+			return nil, fmt.Errorf("duplicate hook id '%s'", n.ID)
+		}
+		byID[n.ID] = n
+	}
+
+	for _, n := range nodes {
+		for _, dep := range n.Hook.DependsOn {
+			if _, ok := byID[dep]; !ok {
+				return nil, fmt.Errorf("hook '%s' declares dependsOn '%s', which does not exist", n.ID, dep)
+			}
+		}
+	}
+
+	done := make(map[string]bool, len(nodes))
+	var waves []HookWave
+
+	for len(done) < len(nodes) {
+		var ready []HookNode
+		for _, n := range nodes {
+			if !done[n.ID] && dependenciesMet(n, done) {
+				ready = append(ready, n)
+			}
+		}
+
+		if len(ready) == 0 {
+			return nil, fmt.Errorf("hooks %v form a dependency cycle", remaining(nodes, done))
+		}
+
+		minWeight := ready[0].Hook.Weight
+		for _, n := range ready[1:] {
+			if n.Hook.Weight < minWeight {
+				minWeight = n.Hook.Weight
+			}
+		}
+
+		wave := make(HookWave, 0, len(ready))
+		for _, n := range ready {
+			if n.Hook.Weight == minWeight {
+				wave = append(wave, n.ID)
+				done[n.ID] = true
+			}
+		}
+
+		waves = append(waves, wave)
+	}
+
+	return waves, nil
+}
+
+func dependenciesMet(n HookNode, done map[string]bool) bool {
+	for _, dep := range n.Hook.DependsOn {
+		if !done[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+func remaining(nodes []HookNode, done map[string]bool) []string {
+	var ids []string
+	for _, n := range nodes {
+		if !done[n.ID] {
+			ids = append(ids, n.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,118 @@
+package ext
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildExecutionPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodes     []HookNode
+		wantWaves []HookWave
+		wantErr   string
+	}{
+		{
+			name: "single wave when no dependencies or weights differ",
+			nodes: []HookNode{
+				{ID: "a", Hook: &HookConfig{}},
+				{ID: "b", Hook: &HookConfig{}},
+			},
+			wantWaves: []HookWave{{"a", "b"}},
+		},
+		{
+			name: "lower weight runs in an earlier wave",
+			nodes: []HookNode{
+				{ID: "a", Hook: &HookConfig{Weight: 10}},
+				{ID: "b", Hook: &HookConfig{Weight: 0}},
+			},
+			wantWaves: []HookWave{{"b"}, {"a"}},
+		},
+		{
+			name: "equal weight ties run concurrently in the same wave",
+			nodes: []HookNode{
+				{ID: "a", Hook: &HookConfig{Weight: 5}},
+				{ID: "b", Hook: &HookConfig{Weight: 5}},
+				{ID: "c", Hook: &HookConfig{Weight: 10}},
+			},
+			wantWaves: []HookWave{{"a", "b"}, {"c"}},
+		},
+		{
+			name: "dependsOn forces a later wave regardless of weight",
+			nodes: []HookNode{
+				{ID: "a", Hook: &HookConfig{Weight: 0, DependsOn: []string{"b"}}},
+				{ID: "b", Hook: &HookConfig{Weight: 10}},
+			},
+			wantWaves: []HookWave{{"b"}, {"a"}},
+		},
+		{
+			name: "missing dependsOn target is an error",
+			nodes: []HookNode{
+				{ID: "a", Hook: &HookConfig{DependsOn: []string{"missing"}}},
+			},
+			wantErr: "hook 'a' declares dependsOn 'missing', which does not exist",
+		},
+		{
+			name: "a cycle is an error",
+			nodes: []HookNode{
+				{ID: "a", Hook: &HookConfig{DependsOn: []string{"b"}}},
+				{ID: "b", Hook: &HookConfig{DependsOn: []string{"a"}}},
+			},
+			wantErr: "form a dependency cycle",
+		},
+		{
+			name: "a duplicate id is an error",
+			nodes: []HookNode{
+				{ID: "a", Hook: &HookConfig{}},
+				{ID: "a", Hook: &HookConfig{}},
+			},
+			wantErr: "duplicate hook id 'a'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waves, err := BuildExecutionPlan(tt.nodes)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(waves) != len(tt.wantWaves) {
+				t.Fatalf("expected %d waves, got %d: %v", len(tt.wantWaves), len(waves), waves)
+			}
+
+			for i, wave := range waves {
+				if !sameIDs(wave, tt.wantWaves[i]) {
+					t.Errorf("wave %d: expected ids %v, got %v", i, tt.wantWaves[i], wave)
+				}
+			}
+		})
+	}
+}
+
+func sameIDs(got, want HookWave) bool {
+	if len(got) != len(want) {
+		return false
+	}
+
+	seen := make(map[string]bool, len(want))
+	for _, id := range want {
+		seen[id] = true
+	}
+
+	for _, id := range got {
+		if !seen[id] {
+			return false
+		}
+	}
+
+	return true
+}
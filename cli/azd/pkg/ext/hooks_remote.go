@@ -0,0 +1,125 @@
+package ext
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// getUserConfigDir resolves the azd user config dir. A package-level var so tests can swap in
+// a temporary directory instead of the real one.
+var getUserConfigDir = config.GetUserConfigDir
+
+// remoteScriptCacheDir returns the shared cache directory remote hook scripts are downloaded
+// into, under the azd user config dir, creating it if necessary.
+func remoteScriptCacheDir() (string, error) {
+	configDir, err := getUserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving azd config dir: %w", err)
+	}
+
+	cacheDir := filepath.Join(configDir, "hooks", "cache")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return "", fmt.Errorf("creating hook script cache dir: %w", err)
+	}
+
+	return cacheDir, nil
+}
+
+// resolveRemoteScript returns the local path to hook's remote script. The cache is keyed by
+// the required digest, so a cache hit is always known-good; a cache miss downloads the script
+// and refuses to return it if the downloaded content doesn't match Sha256.
+func (r *HooksRunner) resolveRemoteScript(ctx context.Context, hook *HookConfig) (string, error) {
+	if hook.Sha256 == "" {
+		return "", fmt.Errorf("hook '%s' has a url but no required sha256 digest", hook.Name)
+	}
+
+	if strings.Contains(hook.Url, "://") && !strings.HasPrefix(hook.Url, "http") {
+		return "", fmt.Errorf("hook '%s': OCI/registry script references are not yet supported", hook.Name)
+	}
+
+	cacheDir, err := remoteScriptCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	cachePath := filepath.Join(cacheDir, hook.Sha256)
+
+	// The cache key is the required digest itself; a file already sitting at cachePath is
+	// reused only when its content still hashes to that digest, and downloaded again otherwise.
+	if digest, err := fileSha256(cachePath); err == nil && digest == hook.Sha256 {
+		return cachePath, nil
+	}
+
+	if r.offline {
+		return "", fmt.Errorf(
+			"hook '%s' requires downloading %s but --offline was set and no cached copy was found",
+			hook.Name, hook.Url,
+		)
+	}
+
+	if err := downloadFile(ctx, hook.Url, cachePath); err != nil {
+		return "", fmt.Errorf("downloading hook '%s' script: %w", hook.Name, err)
+	}
+
+	digest, err := fileSha256(cachePath)
+	if err != nil {
+		return "", fmt.Errorf("hashing downloaded hook '%s' script: %w", hook.Name, err)
+	}
+
+	if digest != hook.Sha256 {
+		_ = os.Remove(cachePath)
+		return "", fmt.Errorf("hook '%s' script digest mismatch: expected %s, got %s", hook.Name, hook.Sha256, digest)
+	}
+
+	return cachePath, nil
+}
+
+func downloadFile(ctx context.Context, url string, dest string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d fetching %s", res.StatusCode, url)
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, res.Body)
+	return err
+}
+
+func fileSha256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,129 @@
+package ext
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func withTempUserConfigDir(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	prev := getUserConfigDir
+	getUserConfigDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { getUserConfigDir = prev })
+}
+
+func digestOf(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestResolveRemoteScript(t *testing.T) {
+	const content = "echo hello\n"
+	digest := digestOf(content)
+
+	t.Run("downloads and caches when nothing is cached yet", func(t *testing.T) {
+		withTempUserConfigDir(t)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(content))
+		}))
+		defer srv.Close()
+
+		r := &HooksRunner{}
+		hook := &HookConfig{Name: "predeploy", Url: srv.URL, Sha256: digest}
+
+		path, err := r.resolveRemoteScript(context.Background(), hook)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading resolved script: %v", err)
+		}
+		if string(got) != content {
+			t.Errorf("expected cached content %q, got %q", content, string(got))
+		}
+	})
+
+	t.Run("reuses a digest-matching cached copy without downloading, even offline", func(t *testing.T) {
+		withTempUserConfigDir(t)
+
+		cacheDir, err := remoteScriptCacheDir()
+		if err != nil {
+			t.Fatalf("preparing cache dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(cacheDir, digest), []byte(content), 0o600); err != nil {
+			t.Fatalf("seeding cache: %v", err)
+		}
+
+		r := &HooksRunner{offline: true}
+		hook := &HookConfig{Name: "predeploy", Url: "http://example.invalid/script.sh", Sha256: digest}
+
+		path, err := r.resolveRemoteScript(context.Background(), hook)
+		if err != nil {
+			t.Fatalf("expected the cached copy to be reused offline, got error: %v", err)
+		}
+		if filepath.Base(path) != digest {
+			t.Errorf("expected cached path, got %s", path)
+		}
+	})
+
+	t.Run("offline with no cached copy fails without downloading", func(t *testing.T) {
+		withTempUserConfigDir(t)
+
+		r := &HooksRunner{offline: true}
+		hook := &HookConfig{Name: "predeploy", Url: "http://example.invalid/script.sh", Sha256: digest}
+
+		_, err := r.resolveRemoteScript(context.Background(), hook)
+		if err == nil || !strings.Contains(err.Error(), "--offline") {
+			t.Fatalf("expected an --offline error, got %v", err)
+		}
+	})
+
+	t.Run("digest mismatch is rejected and the bad copy is removed", func(t *testing.T) {
+		withTempUserConfigDir(t)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("different content"))
+		}))
+		defer srv.Close()
+
+		r := &HooksRunner{}
+		hook := &HookConfig{Name: "predeploy", Url: srv.URL, Sha256: digest}
+
+		_, err := r.resolveRemoteScript(context.Background(), hook)
+		if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+			t.Fatalf("expected a digest mismatch error, got %v", err)
+		}
+
+		cacheDir, cacheErr := remoteScriptCacheDir()
+		if cacheErr != nil {
+			t.Fatalf("resolving cache dir: %v", cacheErr)
+		}
+		if _, statErr := os.Stat(filepath.Join(cacheDir, digest)); !os.IsNotExist(statErr) {
+			t.Errorf("expected the mismatched cache file to be removed, stat err: %v", statErr)
+		}
+	})
+
+	t.Run("missing sha256 is rejected before any network access", func(t *testing.T) {
+		withTempUserConfigDir(t)
+
+		r := &HooksRunner{}
+		hook := &HookConfig{Name: "predeploy", Url: "http://example.invalid/script.sh"}
+
+		_, err := r.resolveRemoteScript(context.Background(), hook)
+		if err == nil || !strings.Contains(err.Error(), "sha256") {
+			t.Fatalf("expected a missing sha256 error, got %v", err)
+		}
+	})
+}
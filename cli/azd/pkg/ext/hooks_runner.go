@@ -0,0 +1,199 @@
+package ext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// HooksRunner executes the hooks configured for a project or service.
+type HooksRunner struct {
+	manager       *HooksManager
+	commandRunner exec.CommandRunner
+	envManager    environment.Manager
+	console       input.Console
+	cwd           string
+	hooks         map[string][]*HookConfig
+	env           *environment.Environment
+	offline       bool
+	onEvent       HookEventSink
+}
+
+// NewHooksRunner creates a HooksRunner that executes the given hooks map from cwd using env
+// as the source of environment variables injected into each hook process. When offline is
+// true, a hook that references a remote script not already present in the local cache fails
+// immediately instead of attempting to download it. onEvent, if non-nil, is called for every
+// lifecycle transition of every hook run and must not block; pass nil to skip the overhead.
+func NewHooksRunner(
+	manager *HooksManager,
+	commandRunner exec.CommandRunner,
+	envManager environment.Manager,
+	console input.Console,
+	cwd string,
+	hooks map[string][]*HookConfig,
+	env *environment.Environment,
+	offline bool,
+	onEvent HookEventSink,
+) *HooksRunner {
+	return &HooksRunner{
+		manager:       manager,
+		commandRunner: commandRunner,
+		envManager:    envManager,
+		console:       console,
+		cwd:           cwd,
+		hooks:         hooks,
+		env:           env,
+		offline:       offline,
+		onEvent:       onEvent,
+	}
+}
+
+// RunHooks executes every hook registered under hookType+commandName, in declaration order.
+// The returned bool is true when at least one hook exhausted its retries but was allowed to
+// continue because of ContinueOnError.
+func (r *HooksRunner) RunHooks(
+	ctx context.Context,
+	hookType HookType,
+	options *tools.ExecOptions,
+	commandName string,
+) (bool, error) {
+	hookName := string(hookType) + commandName
+	warned := false
+
+	for _, hook := range r.hooks[hookName] {
+		hookWarned, err := r.runHook(ctx, hook, options)
+		if err != nil {
+			return warned, err
+		}
+
+		warned = warned || hookWarned
+	}
+
+	return warned, nil
+}
+
+// runHook runs hook, retrying up to hook.Retries times with exponential backoff starting at
+// hook.RetryDelay. If every attempt fails and hook.ContinueOnError is set, the failure is
+// swallowed and warned is returned true so the caller can surface a warning instead of
+// aborting.
+func (r *HooksRunner) runHook(ctx context.Context, hook *HookConfig, options *tools.ExecOptions) (bool, error) {
+	attempts := hook.Retries + 1
+
+	delay := hook.RetryDelay.Duration()
+	if delay <= 0 {
+		delay = time.Second
+	}
+
+	var lastErr error
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		r.publish(HookEvent{Hook: hook.Name, Phase: HookEventStart, Attempt: attempt})
+
+		started := time.Now()
+		lastErr = r.runHookAttempt(ctx, hook, r.wrapOptionsForEvents(hook, attempt, options))
+		duration := time.Since(started)
+
+		exitCode := 0
+		if lastErr != nil {
+			exitCode = 1
+		}
+
+		r.publish(HookEvent{
+			Hook: hook.Name, Phase: HookEventExit, Attempt: attempt,
+			ExitCode: exitCode, Duration: duration, Err: lastErr,
+		})
+
+		if lastErr == nil {
+			return false, nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		if options != nil && options.StdOut != nil {
+			fmt.Fprintf(
+				options.StdOut,
+				"attempt %d/%d for hook '%s' failed: %v, retrying in %s\n",
+				attempt, attempts, hook.Name, lastErr, delay,
+			)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+
+		delay *= 2
+	}
+
+	if hook.ContinueOnError {
+		return true, nil
+	}
+
+	return false, lastErr
+}
+
+// runHookAttempt runs a single attempt of hook, bounded by hook.Timeout when set.
+func (r *HooksRunner) runHookAttempt(ctx context.Context, hook *HookConfig, options *tools.ExecOptions) error {
+	attemptCtx := ctx
+
+	if timeout := hook.Timeout.Duration(); timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var err error
+	if hook.HasContainer() {
+		err = r.runContainerHook(attemptCtx, hook, options)
+	} else {
+		err = r.runHostHook(attemptCtx, hook, options)
+	}
+
+	if errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("hook '%s' timed out after %s: %w", hook.Name, hook.Timeout.Duration(), err)
+	}
+
+	return err
+}
+
+func (r *HooksRunner) runHostHook(ctx context.Context, hook *HookConfig, options *tools.ExecOptions) error {
+	run := hook.Run
+
+	if hook.Url != "" {
+		scriptPath, err := r.resolveRemoteScript(ctx, hook)
+		if err != nil {
+			return err
+		}
+
+		run = scriptPath
+	}
+
+	runArgs := exec.
+		NewRunArgs(hook.Shell, run).
+		WithCwd(r.cwd).
+		WithEnv(r.env.Environ())
+
+	if options != nil {
+		runArgs = runArgs.WithStdOut(options.StdOut)
+	}
+
+	res, err := r.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed running hook '%s': %w", hook.Name, err)
+	}
+
+	if res.ExitCode != 0 {
+		return fmt.Errorf("hook '%s' exited with code %d", hook.Name, res.ExitCode)
+	}
+
+	return nil
+}